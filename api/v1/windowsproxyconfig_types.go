@@ -0,0 +1,64 @@
+package v1
+
+import (
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProxyOverride specifies HTTP_PROXY/HTTPS_PROXY/NO_PROXY values that override the cluster-wide proxy for
+// a single service. Any field left empty falls back to the cluster-wide value for that variable.
+type ProxyOverride struct {
+	// HTTPProxy overrides HTTP_PROXY for the service, if set
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// HTTPSProxy overrides HTTPS_PROXY for the service, if set
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy overrides NO_PROXY for the service, if set
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// WindowsProxyConfigSpec defines the per-service proxy overrides to apply on top of the cluster-wide Proxy
+type WindowsProxyConfigSpec struct {
+	// Services maps a required Windows service name (e.g. "containerd", "kubelet", "hybrid-overlay-node")
+	// to the proxy values that should override the cluster-wide proxy for that service. Services not
+	// listed here continue to use the cluster-wide proxy values unmodified.
+	Services map[string]ProxyOverride `json:"services"`
+}
+
+// WindowsProxyConfigStatus reports the last-applied state of a WindowsProxyConfig
+type WindowsProxyConfigStatus struct {
+	// ObservedGeneration is the most recent generation of the WindowsProxyConfig that has been applied to
+	// the services it scopes
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// AppliedEnvVars records the HTTP_PROXY/HTTPS_PROXY/NO_PROXY values last applied to each scoped
+	// service, keyed by service name. It lets the controller detect that nothing has actually changed
+	// since the last reconcile and skip restarting a service unnecessarily.
+	// +optional
+	AppliedEnvVars map[string]map[string]string `json:"appliedEnvVars,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced
+
+// WindowsProxyConfig lets operators override cluster-wide proxy environment variables on a per-service
+// basis for the Windows services managed by WMCO, for example to give containerd image pulls a different
+// NO_PROXY than kubelet API traffic.
+type WindowsProxyConfig struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WindowsProxyConfigSpec   `json:"spec,omitempty"`
+	Status WindowsProxyConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WindowsProxyConfigList contains a list of WindowsProxyConfig
+type WindowsProxyConfigList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata,omitempty"`
+	Items         []WindowsProxyConfig `json:"items"`
+}
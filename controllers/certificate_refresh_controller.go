@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/openshift/windows-machine-config-operator/pkg/certificates"
+	"github.com/openshift/windows-machine-config-operator/pkg/windows"
+)
+
+// windowsOSLabel is the well-known node label identifying a node's operating system
+const windowsOSLabel = "kubernetes.io/os"
+
+// CertificateRefreshReconciler watches for Nodes annotated with certificates.RefreshAnnotation and runs an
+// on-demand certificate refresh against the corresponding Windows instance, independent of the main
+// WindowsMachineConfig reconciliation loop. This allows an admin to rotate certificates without cordoning
+// or draining the node.
+type CertificateRefreshReconciler struct {
+	client    client.Client
+	log       logr.Logger
+	watchNS   string
+	instances windows.InstanceLister
+}
+
+// NewCertificateRefreshReconciler returns a pointer to a CertificateRefreshReconciler. watchNS is the
+// namespace holding the trusted-ca ConfigMap that is re-synced onto the instance during a refresh.
+func NewCertificateRefreshReconciler(client client.Client, log logr.Logger, watchNS string,
+	instances windows.InstanceLister) *CertificateRefreshReconciler {
+	return &CertificateRefreshReconciler{client: client, log: log, watchNS: watchNS, instances: instances}
+}
+
+// SetupWithManager sets up the controller to watch Windows Nodes, reacting only to the refresh annotation
+func (r *CertificateRefreshReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&core.Node{}, builder.WithPredicates(isWindowsNode)).
+		Complete(r)
+}
+
+// isWindowsNode is true for Nodes labelled as running Windows, filtering out the Linux nodes that make up
+// the bulk of heartbeat-driven Node updates in a typical cluster
+var isWindowsNode = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	return obj.GetLabels()[windowsOSLabel] == "windows"
+})
+
+// Reconcile is invoked whenever a Windows Node object changes. If the Node is annotated with
+// certificates.RefreshAnnotation, the trusted CA bundle and kubelet/CSR-issued certificates on the
+// corresponding Windows instance are re-synced, and the outcome is recorded via status annotations.
+func (r *CertificateRefreshReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("node", request.NamespacedName)
+
+	node := &core.Node{}
+	if err := r.client.Get(ctx, request.NamespacedName, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !certificates.NeedsRefresh(node) {
+		return ctrl.Result{}, nil
+	}
+
+	log.Info("certificate refresh requested, beginning refresh")
+	if err := r.patchAnnotations(ctx, node, certificates.InProgressPatch()); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	addr, err := GetAddress(node.Status.Addresses)
+	if err != nil {
+		return ctrl.Result{}, r.patchAnnotations(ctx, node, certificates.ResultPatch(err))
+	}
+
+	refresher := certificates.NewRefresher(
+		func() error { return r.syncCABundle(ctx, addr) },
+		func() error { return r.syncKubeletCerts(ctx, addr) },
+	)
+	refreshErr := refresher.Refresh()
+	if refreshErr != nil {
+		log.Error(refreshErr, "certificate refresh failed")
+	} else {
+		log.Info("certificate refresh completed")
+	}
+	return ctrl.Result{}, r.patchAnnotations(ctx, node, certificates.ResultPatch(refreshErr))
+}
+
+// patchAnnotations merges the given annotations into the Node, removing any whose value is empty
+func (r *CertificateRefreshReconciler) patchAnnotations(ctx context.Context, node *core.Node, annotations map[string]string) error {
+	patched := node.DeepCopy()
+	if patched.Annotations == nil {
+		patched.Annotations = map[string]string{}
+	}
+	for k, v := range annotations {
+		if v == "" {
+			delete(patched.Annotations, k)
+			continue
+		}
+		patched.Annotations[k] = v
+	}
+	return r.client.Patch(ctx, patched, client.MergeFrom(node))
+}
+
+// syncCABundle reads the cluster's trusted CA bundle ConfigMap and re-imports its contents into the
+// Windows instance at addr's certificate stores
+func (r *CertificateRefreshReconciler) syncCABundle(ctx context.Context, addr string) error {
+	cm := &core.ConfigMap{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: r.watchNS, Name: certificates.ProxyCertsConfigMap}, cm); err != nil {
+		return fmt.Errorf("unable to get %s ConfigMap: %w", certificates.ProxyCertsConfigMap, err)
+	}
+	bundle := cm.Data[certificates.CABundleKey]
+	if bundle == "" {
+		return nil
+	}
+	instance, err := r.instanceAt(ctx, addr)
+	if err != nil {
+		return err
+	}
+	return instance.ImportTrustedCABundle(bundle)
+}
+
+// syncKubeletCerts re-requests and imports the kubelet/CSR-issued certificates for the Windows instance at addr
+func (r *CertificateRefreshReconciler) syncKubeletCerts(ctx context.Context, addr string) error {
+	instance, err := r.instanceAt(ctx, addr)
+	if err != nil {
+		return err
+	}
+	return instance.RenewKubeletServingCert()
+}
+
+// instanceAt returns the windows.Instance whose address matches addr
+func (r *CertificateRefreshReconciler) instanceAt(ctx context.Context, addr string) (windows.Instance, error) {
+	instances, err := r.instances.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list Windows instances: %w", err)
+	}
+	for _, instance := range instances {
+		if instance.Address() == addr {
+			return instance, nil
+		}
+	}
+	return nil, fmt.Errorf("no Windows instance found for address %s", addr)
+}
@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/go-logr/logr"
+	config "github.com/openshift/api/config/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	wmcov1 "github.com/openshift/windows-machine-config-operator/api/v1"
+	"github.com/openshift/windows-machine-config-operator/pkg/windows"
+)
+
+// WindowsProxyConfigReconciler reconciles WindowsProxyConfig objects, merging their per-service overrides
+// with the cluster-wide Proxy and re-rendering the affected service definitions on each Windows instance.
+// Unlike the cluster-wide Proxy, which is applied uniformly to every entry in windows.RequiredServices,
+// a WindowsProxyConfig lets an operator scope different proxy values to individual services.
+type WindowsProxyConfigReconciler struct {
+	client       client.Client
+	log          logr.Logger
+	instances    windows.InstanceLister
+	clusterProxy func(ctx context.Context) (*config.Proxy, error)
+}
+
+// NewWindowsProxyConfigReconciler returns a pointer to a WindowsProxyConfigReconciler
+func NewWindowsProxyConfigReconciler(client client.Client, log logr.Logger, instances windows.InstanceLister,
+	clusterProxy func(ctx context.Context) (*config.Proxy, error)) *WindowsProxyConfigReconciler {
+	return &WindowsProxyConfigReconciler{client: client, log: log, instances: instances, clusterProxy: clusterProxy}
+}
+
+// +kubebuilder:rbac:groups=wmco.openshift.io,resources=windowsproxyconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=wmco.openshift.io,resources=windowsproxyconfigs/status,verbs=get;update;patch
+
+// SetupWithManager adds the WindowsProxyConfig type to mgr's scheme and sets up the controller to watch
+// WindowsProxyConfig objects, and also the cluster-wide Proxy object so that a change to the cluster-wide
+// proxy re-triggers every WindowsProxyConfig that merges values on top of it, rather than going stale until
+// the CR itself is next touched.
+func (r *WindowsProxyConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := wmcov1.AddToScheme(mgr.GetScheme()); err != nil {
+		return fmt.Errorf("unable to add WindowsProxyConfig to scheme: %w", err)
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&wmcov1.WindowsProxyConfig{}).
+		Watches(&source.Kind{Type: &config.Proxy{}}, handler.EnqueueRequestsFromMapFunc(r.requestsForAllConfigs)).
+		Complete(r)
+}
+
+// requestsForAllConfigs maps any cluster-wide Proxy change to a reconcile request for every existing
+// WindowsProxyConfig, since any of them may merge values on top of the proxy that just changed
+func (r *WindowsProxyConfigReconciler) requestsForAllConfigs(obj client.Object) []ctrl.Request {
+	configs := &wmcov1.WindowsProxyConfigList{}
+	if err := r.client.List(context.Background(), configs); err != nil {
+		r.log.Error(err, "unable to list WindowsProxyConfigs in response to cluster-wide proxy change")
+		return nil
+	}
+	requests := make([]ctrl.Request, 0, len(configs.Items))
+	for _, c := range configs.Items {
+		requests = append(requests, ctrl.Request{NamespacedName: types.NamespacedName{Namespace: c.Namespace, Name: c.Name}})
+	}
+	return requests
+}
+
+// Reconcile merges the cluster-wide proxy with the overrides in the WindowsProxyConfig named in request,
+// re-renders the affected service definitions on each Windows instance, and restarts only those services
+func (r *WindowsProxyConfigReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	log := r.log.WithValues("windowsproxyconfig", request.NamespacedName)
+
+	proxyConfig := &wmcov1.WindowsProxyConfig{}
+	if err := r.client.Get(ctx, request.NamespacedName, proxyConfig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	clusterProxy, err := r.clusterProxy(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to get cluster-wide proxy: %w", err)
+	}
+
+	instances, err := r.instances.List(ctx)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to list Windows instances: %w", err)
+	}
+
+	appliedEnvVars := make(map[string]map[string]string, len(proxyConfig.Spec.Services))
+	for svcName, override := range proxyConfig.Spec.Services {
+		envVars := mergeProxyOverride(clusterProxy, override)
+		appliedEnvVars[svcName] = envVars
+
+		if reflect.DeepEqual(proxyConfig.Status.AppliedEnvVars[svcName], envVars) {
+			log.V(1).Info("no change in proxy override, skipping restart", "service", svcName)
+			continue
+		}
+
+		for _, instance := range instances {
+			if err := instance.UpdateServiceEnvironment(svcName, envVars); err != nil {
+				return ctrl.Result{}, fmt.Errorf("unable to update %s environment on %s: %w", svcName, instance.Address(), err)
+			}
+			if err := instance.RestartService(svcName); err != nil {
+				return ctrl.Result{}, fmt.Errorf("unable to restart %s on %s: %w", svcName, instance.Address(), err)
+			}
+			log.Info("applied per-service proxy override", "service", svcName, "instance", instance.Address())
+		}
+	}
+
+	proxyConfig.Status.ObservedGeneration = proxyConfig.Generation
+	proxyConfig.Status.AppliedEnvVars = appliedEnvVars
+	if err := r.client.Status().Update(ctx, proxyConfig); err != nil {
+		return ctrl.Result{}, fmt.Errorf("unable to update WindowsProxyConfig status: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// mergeProxyOverride returns the HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables for a service,
+// falling back to the cluster-wide value for any field the override leaves empty
+func mergeProxyOverride(clusterProxy *config.Proxy, override wmcov1.ProxyOverride) map[string]string {
+	envVars := map[string]string{
+		"HTTP_PROXY":  clusterProxy.Status.HTTPProxy,
+		"HTTPS_PROXY": clusterProxy.Status.HTTPSProxy,
+		"NO_PROXY":    clusterProxy.Status.NoProxy,
+	}
+	if override.HTTPProxy != "" {
+		envVars["HTTP_PROXY"] = override.HTTPProxy
+	}
+	if override.HTTPSProxy != "" {
+		envVars["HTTPS_PROXY"] = override.HTTPSProxy
+	}
+	if override.NoProxy != "" {
+		envVars["NO_PROXY"] = override.NoProxy
+	}
+	return envVars
+}
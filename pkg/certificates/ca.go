@@ -0,0 +1,167 @@
+package certificates
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+)
+
+// CA is a certificate authority that can issue intermediate CAs and leaf certificates signed by itself.
+// It exists primarily to give e2e tests a realistic, CA-signed chain of trust to validate against, rather
+// than the single self-signed certificate WMCO would never actually encounter in practice.
+type CA struct {
+	certPEM string
+	cert    *x509.Certificate
+	key     *rsa.PrivateKey
+}
+
+// NewCA generates a new 2048-bit root certificate authority
+func NewCA(commonName string) (*CA, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate CA key: %w", err)
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"WMCO Test Org."}},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().AddDate(10, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to self-sign CA certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &CA{certPEM: encodePEM(certBytes), cert: cert, key: key}, nil
+}
+
+// CertPEM returns the CA's own certificate in PEM form
+func (ca *CA) CertPEM() string {
+	return ca.certPEM
+}
+
+// NewIntermediate issues a new intermediate CA signed by ca and returns it as a CA that can itself issue
+// leaf certificates. Its PEM-encoded certificate is available via CertPEM.
+func (ca *CA) NewIntermediate(commonName string) (*CA, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate intermediate CA key: %w", err)
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"WMCO Test Org."}},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().AddDate(5, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign intermediate CA certificate: %w", err)
+	}
+	cert, err := x509.ParseCertificate(certBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &CA{certPEM: encodePEM(certBytes), cert: cert, key: key}, nil
+}
+
+// NewLeaf issues a new leaf certificate signed by ca for the given SANs, valid for the given duration.
+// Returns the PEM-encoded certificate and private key.
+func (ca *CA) NewLeaf(commonName string, sans []string, validity time.Duration) (certPEM, keyPEM string, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to generate leaf key: %w", err)
+	}
+	serial, err := newSerialNumber()
+	if err != nil {
+		return "", "", err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName, Organization: []string{"WMCO Test Org."}},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	for _, san := range sans {
+		if ip := net.ParseIP(san); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, san)
+		}
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to sign leaf certificate: %w", err)
+	}
+	keyBytes := x509.MarshalPKCS1PrivateKey(key)
+	keyPEMBuf := new(bytes.Buffer)
+	if err := pem.Encode(keyPEMBuf, &pem.Block{Type: "RSA PRIVATE KEY", Bytes: keyBytes}); err != nil {
+		return "", "", err
+	}
+	return encodePEM(certBytes), keyPEMBuf.String(), nil
+}
+
+// ParseCertAndKey parses a PEM-encoded certificate and private key pair, returning the decoded
+// *x509.Certificate and *rsa.PrivateKey
+func ParseCertAndKey(certPEM, keyPEM []byte) (*x509.Certificate, *rsa.PrivateKey, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return nil, nil, fmt.Errorf("unable to decode certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse certificate: %w", err)
+	}
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, nil, fmt.Errorf("unable to decode private key PEM")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to parse private key: %w", err)
+	}
+	return cert, key, nil
+}
+
+// encodePEM encodes raw DER certificate bytes as a PEM-formatted string
+func encodePEM(certBytes []byte) string {
+	buf := new(bytes.Buffer)
+	pem.Encode(buf, &pem.Block{Type: "CERTIFICATE", Bytes: certBytes})
+	return buf.String()
+}
+
+// newSerialNumber generates a random certificate serial number
+func newSerialNumber() (*big.Int, error) {
+	limit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serial, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate certificate serial number: %w", err)
+	}
+	return serial, nil
+}
@@ -0,0 +1,47 @@
+package certificates
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNewLeafRoundTripsThroughParseCertAndKey verifies that a leaf certificate/key pair issued by NewLeaf
+// can be parsed back with ParseCertAndKey, and that the parsed certificate actually chains up through its
+// issuing intermediate to the root.
+func TestNewLeafRoundTripsThroughParseCertAndKey(t *testing.T) {
+	root, err := NewCA("test root")
+	require.NoError(t, err)
+	intermediate, err := root.NewIntermediate("test intermediate")
+	require.NoError(t, err)
+	leafCertPEM, leafKeyPEM, err := intermediate.NewLeaf("test.example.com", []string{"test.example.com", "127.0.0.1"}, time.Hour)
+	require.NoError(t, err)
+
+	leafCert, leafKey, err := ParseCertAndKey([]byte(leafCertPEM), []byte(leafKeyPEM))
+	require.NoError(t, err)
+
+	assert.Equal(t, "test.example.com", leafCert.Subject.CommonName)
+	assert.True(t, leafKey.PublicKey.Equal(leafCert.PublicKey), "parsed key does not match parsed certificate's public key")
+
+	roots := x509.NewCertPool()
+	rootCert, _, err := ParseCertAndKey([]byte(root.CertPEM()), []byte(leafKeyPEM))
+	require.NoError(t, err, "unable to parse root cert")
+	roots.AddCert(rootCert)
+
+	intermediates := x509.NewCertPool()
+	intermediateCert, _, err := ParseCertAndKey([]byte(intermediate.CertPEM()), []byte(leafKeyPEM))
+	require.NoError(t, err, "unable to parse intermediate cert")
+	intermediates.AddCert(intermediateCert)
+
+	_, err = leafCert.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates, DNSName: "test.example.com"})
+	assert.NoError(t, err, "leaf certificate did not verify against its issuing chain")
+}
+
+// TestParseCertAndKeyInvalidPEM ensures malformed input is surfaced as an error
+func TestParseCertAndKeyInvalidPEM(t *testing.T) {
+	_, _, err := ParseCertAndKey([]byte("not a cert"), []byte("not a key"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,23 @@
+package certificates
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	refreshResultSuccess = "success"
+	refreshResultFailure = "failure"
+)
+
+var (
+	// refreshTotal tracks the number of on-demand certificate refreshes WMCO has performed, by result
+	refreshTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "wmco_certificate_refresh_total",
+		Help: "Number of on-demand certificate refreshes performed, by result (success or failure)",
+	}, []string{"result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(refreshTotal)
+}
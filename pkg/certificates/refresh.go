@@ -0,0 +1,94 @@
+package certificates
+
+import (
+	"fmt"
+	"time"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// RefreshAnnotation is set by an admin on a Node to request an on-demand refresh of that instance's
+	// trusted CA bundle and kubelet/CSR-issued certificates, independent of the normal reconciliation loop.
+	// Only Nodes are watched for it; the annotation is not acted on when set on a Machine. WMCO removes it
+	// when it records the outcome of the refresh.
+	RefreshAnnotation = "windowsmachineconfig.openshift.io/refresh-certificates"
+	// RefreshStatusAnnotation records the outcome of the most recent certificate refresh requested via
+	// RefreshAnnotation. Its value is one of the RefreshStatus constants below.
+	RefreshStatusAnnotation = "windowsmachineconfig.openshift.io/refresh-certificates-status"
+	// RefreshTimestampAnnotation records the RFC3339 timestamp of the last certificate refresh attempt,
+	// successful or not.
+	RefreshTimestampAnnotation = "windowsmachineconfig.openshift.io/refresh-certificates-timestamp"
+	// RefreshErrorAnnotation holds the error message from the most recent failed refresh. It is cleared
+	// on the next successful refresh.
+	RefreshErrorAnnotation = "windowsmachineconfig.openshift.io/refresh-certificates-error"
+
+	// RefreshStatusInProgress indicates WMCO has started processing a refresh request
+	RefreshStatusInProgress = "in-progress"
+	// RefreshStatusDone indicates the most recent refresh completed successfully
+	RefreshStatusDone = "done"
+	// RefreshStatusFailed indicates the most recent refresh did not complete successfully, see
+	// RefreshErrorAnnotation for details
+	RefreshStatusFailed = "failed"
+)
+
+// NeedsRefresh returns true if the given object has been annotated to request a certificate refresh whose
+// outcome has not yet been recorded by WMCO
+func NeedsRefresh(obj meta.Object) bool {
+	return obj.GetAnnotations()[RefreshAnnotation] == "true"
+}
+
+// InProgressPatch returns the annotation set marking a refresh as having started
+func InProgressPatch() map[string]string {
+	return map[string]string{
+		RefreshStatusAnnotation:    RefreshStatusInProgress,
+		RefreshTimestampAnnotation: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// ResultPatch returns the annotation set that should be applied to a Node or Machine to record the
+// outcome of a certificate refresh attempt and clear the request. A nil refreshErr indicates success.
+func ResultPatch(refreshErr error) map[string]string {
+	patch := map[string]string{
+		RefreshAnnotation:          "",
+		RefreshStatusAnnotation:    RefreshStatusDone,
+		RefreshTimestampAnnotation: time.Now().UTC().Format(time.RFC3339),
+		RefreshErrorAnnotation:     "",
+	}
+	if refreshErr != nil {
+		patch[RefreshStatusAnnotation] = RefreshStatusFailed
+		patch[RefreshErrorAnnotation] = refreshErr.Error()
+	}
+	return patch
+}
+
+// Refresher re-syncs the trusted CA bundle and any kubelet/CSR-issued certificates on a single Windows
+// instance. It is invoked explicitly in response to RefreshAnnotation and does not participate in the
+// regular reconciliation loop, so a refresh can be requested without cordoning or draining the node.
+type Refresher struct {
+	// syncCABundle imports the current trusted CA bundle into the instance's certificate stores
+	syncCABundle func() error
+	// syncKubeletCerts re-requests and imports the kubelet/CSR-issued certificates for the instance
+	syncKubeletCerts func() error
+}
+
+// NewRefresher returns a Refresher that uses the given functions to sync the CA bundle and kubelet certs
+// for a single Windows instance
+func NewRefresher(syncCABundle, syncKubeletCerts func() error) *Refresher {
+	return &Refresher{syncCABundle: syncCABundle, syncKubeletCerts: syncKubeletCerts}
+}
+
+// Refresh runs the certificate refresh workflow to completion, recording metrics for the outcome. It is
+// idempotent: running it multiple times in succession has the same effect as running it once.
+func (r *Refresher) Refresh() error {
+	if err := r.syncCABundle(); err != nil {
+		refreshTotal.WithLabelValues(refreshResultFailure).Inc()
+		return fmt.Errorf("unable to refresh trusted CA bundle: %w", err)
+	}
+	if err := r.syncKubeletCerts(); err != nil {
+		refreshTotal.WithLabelValues(refreshResultFailure).Inc()
+		return fmt.Errorf("unable to refresh kubelet certificates: %w", err)
+	}
+	refreshTotal.WithLabelValues(refreshResultSuccess).Inc()
+	return nil
+}
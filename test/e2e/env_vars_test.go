@@ -0,0 +1,80 @@
+package e2e
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseWindowsEnvVars exercises parseWindowsEnvVars against the ConvertTo-Json output it expects,
+// including cases the previous Format-List line scraping could not handle reliably.
+func TestParseWindowsEnvVars(t *testing.T) {
+	longNoProxy := make([]string, 35)
+	for i := range longNoProxy {
+		longNoProxy[i] = "host" + strings.Repeat("x", i%5) + ".example.com"
+	}
+	longNoProxyValue := strings.Join(longNoProxy, ",")
+
+	tests := []struct {
+		name     string
+		pwshOut  string
+		expected map[string]string
+	}{
+		{
+			name:     "single variable",
+			pwshOut:  `{"HTTP_PROXY":"http://proxy.example.com:3128/"}`,
+			expected: map[string]string{"HTTP_PROXY": "http://proxy.example.com:3128/"},
+		},
+		{
+			name:     "value containing colons and equals",
+			pwshOut:  `{"HTTPS_PROXY":"https://user:p@ss=word@proxy.example.com:3129/"}`,
+			expected: map[string]string{"HTTPS_PROXY": "https://user:p@ss=word@proxy.example.com:3129/"},
+		},
+		{
+			name:     "empty value",
+			pwshOut:  `{"NO_PROXY":""}`,
+			expected: map[string]string{"NO_PROXY": ""},
+		},
+		{
+			name:     "unicode value",
+			pwshOut:  `{"GREETING":"héllo wörld \u6d4b\u8bd5"}`,
+			expected: map[string]string{"GREETING": "héllo wörld 测试"},
+		},
+		{
+			name:     "NO_PROXY with 30+ comma-separated entries",
+			pwshOut:  `{"NO_PROXY":"` + longNoProxyValue + `"}`,
+			expected: map[string]string{"NO_PROXY": longNoProxyValue},
+		},
+		{
+			name:     "multiple variables",
+			pwshOut:  `{"HTTP_PROXY":"http://a:3128/","NO_PROXY":"b.com,c.com","SHELL":"c:\\windows\\system32\\cmd.exe"}`,
+			expected: map[string]string{"HTTP_PROXY": "http://a:3128/", "NO_PROXY": "b.com,c.com", "SHELL": `c:\windows\system32\cmd.exe`},
+		},
+		{
+			name:     "job wrapper noise around the JSON payload",
+			pwshOut:  "Job started\n{\"HTTP_PROXY\":\"http://a:3128/\"}\nJob completed successfully",
+			expected: map[string]string{"HTTP_PROXY": "http://a:3128/"},
+		},
+		{
+			name:     "no environment variables found",
+			pwshOut:  "Job started\nJob completed successfully",
+			expected: map[string]string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := parseWindowsEnvVars(test.pwshOut)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+// TestParseWindowsEnvVarsInvalidJSON ensures malformed JSON is surfaced as an error rather than silently
+// producing an incomplete or incorrect map
+func TestParseWindowsEnvVarsInvalidJSON(t *testing.T) {
+	_, err := parseWindowsEnvVars(`{"HTTP_PROXY":}`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,263 @@
+package e2e
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apps "k8s.io/api/apps/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/openshift/windows-machine-config-operator/controllers"
+	"github.com/openshift/windows-machine-config-operator/pkg/patch"
+	"github.com/openshift/windows-machine-config-operator/pkg/retry"
+)
+
+const (
+	// squidNamespace is the namespace the in-cluster Squid proxy is deployed to for traffic validation
+	squidNamespace = "wmco-e2e-squid"
+	// squidDeploymentName is the name of the Squid Deployment and its fronting Service
+	squidDeploymentName = "wmco-e2e-squid"
+	// squidPort is the port Squid listens for proxy traffic on
+	squidPort = 3128
+	// squidTLSCertSecretName holds the TLS-terminating certificate Squid uses for HTTPS interception
+	squidTLSCertSecretName = "wmco-e2e-squid-tls"
+	// squidConfigMapName holds squid.conf, which wires squidTLSCertSecretName into an https_port directive
+	squidConfigMapName = "wmco-e2e-squid-conf"
+	// squidTLSMountPath is where squidTLSCertSecretName is mounted in the Squid container
+	squidTLSMountPath = "/etc/squid/tls"
+	// squidConfMountPath is where squidConfigMapName is mounted in the Squid container
+	squidConfMountPath = "/etc/squid"
+	// inClusterTestURL is an in-cluster URL that should appear in NO_PROXY and therefore bypass the proxy
+	inClusterTestURL = "https://kubernetes.default.svc"
+	// externalTestURL is reached only through the proxy
+	externalTestURL = "https://www.example.com"
+)
+
+// proxiedTrafficTestSuite validates that outbound traffic from a Windows workload actually flows through
+// the cluster-wide proxy, rather than merely asserting that the proxy environment variables are set.
+// It is skipped if a proxy is not enabled in the test environment.
+func proxiedTrafficTestSuite(t *testing.T) {
+	tc, err := NewTestContext()
+	require.NoError(t, err)
+
+	proxyEnabled, err := tc.client.ProxyEnabled()
+	require.NoErrorf(t, err, "error checking if proxy is enabled in test environment")
+	if !proxyEnabled {
+		t.Skip("cluster-wide proxy is not enabled in this environment")
+	}
+	require.NoError(t, tc.loadExistingNodes())
+
+	require.NoError(t, tc.deploySquidProxy(), "unable to deploy Squid proxy")
+	t.Cleanup(func() {
+		_ = tc.client.K8s.CoreV1().Namespaces().Delete(context.TODO(), squidNamespace, meta.DeleteOptions{})
+	})
+
+	require.NoError(t, tc.patchProxyToSquid(), "unable to point cluster-wide proxy at Squid")
+
+	t.Run("Proxied traffic validation", tc.testProxiedTraffic)
+}
+
+// deploySquidProxy creates a namespace, TLS secret, Deployment, and Service running Squid with access
+// logging enabled, fronted by a certificate from the CA chain so HTTPS interception is trusted by nodes
+// that already import trustChain via the user-provided CA bundle.
+func (tc *testContext) deploySquidProxy() error {
+	ns := &core.Namespace{ObjectMeta: meta.ObjectMeta{Name: squidNamespace}}
+	if _, err := tc.client.K8s.CoreV1().Namespaces().Create(context.TODO(), ns, meta.CreateOptions{}); err != nil {
+		return fmt.Errorf("error creating namespace %s: %w", squidNamespace, err)
+	}
+
+	tlsCertPEM, tlsKeyPEM, err := trustChain.intermediate.NewLeaf(squidDeploymentName+"."+squidNamespace+".svc",
+		[]string{squidDeploymentName + "." + squidNamespace + ".svc"}, 24*time.Hour)
+	if err != nil {
+		return fmt.Errorf("unable to generate Squid TLS certificate: %w", err)
+	}
+	secret := &core.Secret{
+		ObjectMeta: meta.ObjectMeta{Name: squidTLSCertSecretName, Namespace: squidNamespace},
+		Data: map[string][]byte{
+			"tls.crt": []byte(tlsCertPEM),
+			"tls.key": []byte(tlsKeyPEM),
+		},
+		Type: core.SecretTypeTLS,
+	}
+	if _, err := tc.client.K8s.CoreV1().Secrets(squidNamespace).Create(context.TODO(), secret, meta.CreateOptions{}); err != nil {
+		return fmt.Errorf("error creating Squid TLS secret: %w", err)
+	}
+
+	squidConf := &core.ConfigMap{
+		ObjectMeta: meta.ObjectMeta{Name: squidConfigMapName, Namespace: squidNamespace},
+		Data:       map[string]string{"squid.conf": renderSquidConfig()},
+	}
+	if _, err := tc.client.K8s.CoreV1().ConfigMaps(squidNamespace).Create(context.TODO(), squidConf, meta.CreateOptions{}); err != nil {
+		return fmt.Errorf("error creating Squid ConfigMap: %w", err)
+	}
+
+	replicas := int32(1)
+	deployment := &apps.Deployment{
+		ObjectMeta: meta.ObjectMeta{Name: squidDeploymentName, Namespace: squidNamespace},
+		Spec: apps.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &meta.LabelSelector{MatchLabels: map[string]string{"app": squidDeploymentName}},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: meta.ObjectMeta{Labels: map[string]string{"app": squidDeploymentName}},
+				Spec: core.PodSpec{
+					Containers: []core.Container{
+						{
+							Name:    "squid",
+							Image:   "quay.io/wmco/squid:latest",
+							Command: []string{"squid", "-f", squidConfMountPath + "/squid.conf", "-NYC"},
+							Ports:   []core.ContainerPort{{ContainerPort: squidPort}},
+							VolumeMounts: []core.VolumeMount{
+								{Name: "tls", MountPath: squidTLSMountPath, ReadOnly: true},
+								{Name: "conf", MountPath: squidConfMountPath, ReadOnly: true},
+							},
+						},
+					},
+					Volumes: []core.Volume{
+						{Name: "tls", VolumeSource: core.VolumeSource{Secret: &core.SecretVolumeSource{SecretName: squidTLSCertSecretName}}},
+						{Name: "conf", VolumeSource: core.VolumeSource{ConfigMap: &core.ConfigMapVolumeSource{
+							LocalObjectReference: core.LocalObjectReference{Name: squidConfigMapName}}}},
+					},
+				},
+			},
+		},
+	}
+	if _, err := tc.client.K8s.AppsV1().Deployments(squidNamespace).Create(context.TODO(), deployment, meta.CreateOptions{}); err != nil {
+		return fmt.Errorf("error creating Squid deployment: %w", err)
+	}
+
+	service := &core.Service{
+		ObjectMeta: meta.ObjectMeta{Name: squidDeploymentName, Namespace: squidNamespace},
+		Spec: core.ServiceSpec{
+			Selector: map[string]string{"app": squidDeploymentName},
+			Ports:    []core.ServicePort{{Port: squidPort, TargetPort: intstr.FromInt(squidPort)}},
+		},
+	}
+	if _, err := tc.client.K8s.CoreV1().Services(squidNamespace).Create(context.TODO(), service, meta.CreateOptions{}); err != nil {
+		return fmt.Errorf("error creating Squid service: %w", err)
+	}
+
+	return wait.PollImmediate(retry.Interval, retry.ResourceChangeTimeout, func() (bool, error) {
+		d, err := tc.client.K8s.AppsV1().Deployments(squidNamespace).Get(context.TODO(), squidDeploymentName, meta.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return d.Status.ReadyReplicas == replicas, nil
+	})
+}
+
+// renderSquidConfig returns a squid.conf that terminates HTTPS on squidPort with the certificate and key
+// mounted from squidTLSCertSecretName at squidTLSMountPath, so the client's CONNECT tunnel is trusted via
+// trustChain, and logs every request to stdout so testProxiedTraffic can scrape it for proof that a given
+// request actually traversed the proxy. This is TLS termination only, not MITM: Squid is not asked to
+// decrypt and re-encrypt the tunneled destination traffic, so ssl-bump is deliberately not set.
+func renderSquidConfig() string {
+	return fmt.Sprintf("https_port %d cert=%s/tls.crt key=%s/tls.key\n"+
+		"acl SSL_ports port 443\n"+
+		"http_access allow all\n"+
+		"access_log stdio:/dev/stdout squid\n"+
+		"cache deny all\n",
+		squidPort, squidTLSMountPath, squidTLSMountPath)
+}
+
+// patchProxyToSquid points the cluster-wide proxy's HTTP(S)_PROXY at the in-cluster Squid service, while
+// leaving NO_PROXY untouched so the in-cluster bypass behavior can be validated alongside the external case
+func (tc *testContext) patchProxyToSquid() error {
+	squidURL := fmt.Sprintf("https://%s.%s.svc:%d", squidDeploymentName, squidNamespace, squidPort)
+	patches := []*patch.JSONPatch{
+		patch.NewJSONPatch("replace", "/spec/httpProxy", squidURL),
+		patch.NewJSONPatch("replace", "/spec/httpsProxy", squidURL),
+	}
+	patchData, err := json.Marshal(patches)
+	if err != nil {
+		return fmt.Errorf("invalid patch data %v: %w", patches, err)
+	}
+	_, err = tc.client.Config.ConfigV1().Proxies().Patch(context.TODO(), "cluster", types.JSONPatchType, patchData,
+		meta.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to patch proxy with Squid address: %w", err)
+	}
+	return nil
+}
+
+// testProxiedTraffic runs Invoke-WebRequest against an external and an in-cluster URL from each Windows
+// node, then scrapes Squid's access log to confirm external traffic traversed the proxy while the
+// in-cluster, NO_PROXY-matched request bypassed it.
+func (tc *testContext) testProxiedTraffic(t *testing.T) {
+	for _, node := range gc.allNodes() {
+		t.Run(node.GetName(), func(t *testing.T) {
+			addr, err := controllers.GetAddress(node.Status.Addresses)
+			require.NoError(t, err, "unable to get node address")
+
+			marker := fmt.Sprintf("wmco-e2e-%s", node.GetName())
+			command := fmt.Sprintf("Invoke-WebRequest -Uri %s -UseBasicParsing | Out-Null; "+
+				"Invoke-WebRequest -Uri %s -UseBasicParsing | Out-Null; Write-Output %s",
+				externalTestURL, inClusterTestURL, marker)
+			_, err = tc.runPowerShellSSHJob("proxied-traffic", command, addr)
+			require.NoError(t, err, "error running proxied traffic requests")
+
+			accessLog, err := tc.squidAccessLog()
+			require.NoError(t, err, "error reading Squid access log")
+
+			// A CONNECT-tunnelled HTTPS request shows up in Squid's access log as "CONNECT host:port",
+			// not the scheme-qualified URL that was actually requested.
+			externalConnect := "CONNECT " + mustHostPort(externalTestURL)
+			inClusterConnect := "CONNECT " + mustHostPort(inClusterTestURL)
+
+			assert.Containsf(t, accessLog, externalConnect, "external request from node %s did not traverse the proxy",
+				node.GetName())
+			assert.NotContainsf(t, accessLog, inClusterConnect, "in-cluster request from node %s unexpectedly traversed the proxy",
+				node.GetName())
+		})
+	}
+}
+
+// mustHostPort returns the host:port Squid logs a CONNECT tunnel to rawURL under, defaulting to port 443
+// since every URL tested here is HTTPS. It panics on a malformed rawURL, which would indicate a bug in one
+// of the test's own URL constants rather than anything environmental.
+func mustHostPort(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		panic(fmt.Sprintf("invalid test URL %q: %s", rawURL, err))
+	}
+	if parsed.Port() != "" {
+		return parsed.Host
+	}
+	return parsed.Host + ":443"
+}
+
+// squidAccessLog returns the contents of the access log emitted by the Squid container's stdout
+func (tc *testContext) squidAccessLog() (string, error) {
+	pods, err := tc.client.K8s.CoreV1().Pods(squidNamespace).List(context.TODO(),
+		meta.ListOptions{LabelSelector: "app=" + squidDeploymentName})
+	if err != nil {
+		return "", fmt.Errorf("error listing Squid pods: %w", err)
+	}
+	if len(pods.Items) == 0 {
+		return "", fmt.Errorf("no Squid pods found in namespace %s", squidNamespace)
+	}
+	req := tc.client.K8s.CoreV1().Pods(squidNamespace).GetLogs(pods.Items[0].Name, &core.PodLogOptions{})
+	stream, err := req.Stream(context.TODO())
+	if err != nil {
+		return "", fmt.Errorf("error streaming Squid access log: %w", err)
+	}
+	defer stream.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return strings.Join(lines, "\n"), scanner.Err()
+}